@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/docker/docker/client"
+	"github.com/hashicorp/consul/api"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetesapi "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"time"
+)
+
+// BackendConfig describes a single load-balancer backend provider to check,
+// discriminated by Type. Only the fields relevant to that Type need be set.
+type BackendConfig struct {
+	Type               string // consul, kubernetes, docker, etcd
+	Host               string
+	Token              string
+	Namespace          string
+	Scheme             string
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+}
+
+// BackendProvider is implemented by anything that can report whether a
+// single load-balancer backend (Consul, Kubernetes, Docker, etcd, ...) is
+// healthy.
+type BackendProvider interface {
+	Healthy(ctx context.Context) (bool, error)
+}
+
+// newBackendProvider builds the BackendProvider for a BackendConfig's Type.
+func newBackendProvider(cfg BackendConfig) (BackendProvider, error) {
+	switch cfg.Type {
+	case "", "consul":
+		return ConsulBackend{
+			Host:               cfg.Host,
+			Scheme:             cfg.Scheme,
+			Token:              cfg.Token,
+			CACertFile:         cfg.CACertFile,
+			ClientCertFile:     cfg.ClientCertFile,
+			ClientKeyFile:      cfg.ClientKeyFile,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		}, nil
+	case "kubernetes":
+		return newKubernetesBackend(cfg)
+	case "docker":
+		return newDockerBackend(cfg)
+	case "etcd":
+		return newEtcdBackend(cfg)
+	default:
+		return nil, fmt.Errorf("unknown backend type %q", cfg.Type)
+	}
+}
+
+// ConsulBackend checks that a Consul cluster has an elected leader.
+type ConsulBackend struct {
+	Host               string
+	Scheme             string
+	Token              string
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+}
+
+func (b ConsulBackend) Healthy(ctx context.Context) (bool, error) {
+	config := api.Config{
+		Address: b.Host,
+		Scheme:  b.Scheme,
+		Token:   resolveSecret(b.Token),
+		TLSConfig: api.TLSConfig{
+			CAFile:             b.CACertFile,
+			CertFile:           b.ClientCertFile,
+			KeyFile:            b.ClientKeyFile,
+			InsecureSkipVerify: b.InsecureSkipVerify,
+		},
+	}
+
+	client, err := api.NewClient(&config)
+
+	if err != nil {
+		return false, fmt.Errorf("error connecting to consul client: %w", err)
+	}
+
+	status := client.Status()
+	leader, err := status.Leader()
+
+	if err != nil {
+		return false, fmt.Errorf("error querying consul leader: %w", err)
+	}
+
+	return leader != "", nil
+}
+
+// KubernetesBackend checks the API server's /readyz endpoint and confirms
+// the client's ingress/CRD watches are established.
+type KubernetesBackend struct {
+	clientset *kubernetesapi.Clientset
+	Namespace string
+}
+
+func newKubernetesBackend(cfg BackendConfig) (KubernetesBackend, error) {
+	restConfig := &rest.Config{
+		Host:        cfg.Host,
+		BearerToken: cfg.Token,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: cfg.InsecureSkipVerify,
+			CAFile:   cfg.CACertFile,
+			CertFile: cfg.ClientCertFile,
+			KeyFile:  cfg.ClientKeyFile,
+		},
+	}
+
+	clientset, err := kubernetesapi.NewForConfig(restConfig)
+
+	if err != nil {
+		return KubernetesBackend{}, fmt.Errorf("error building kubernetes client: %w", err)
+	}
+
+	return KubernetesBackend{clientset: clientset, Namespace: cfg.Namespace}, nil
+}
+
+func (b KubernetesBackend) Healthy(ctx context.Context) (bool, error) {
+	body, err := b.clientset.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("error contacting kubernetes /readyz: %w", err)
+	}
+
+	if string(body) != "ok" {
+		return false, fmt.Errorf("kubernetes /readyz reported not ready: %s", body)
+	}
+
+	if _, err := b.clientset.NetworkingV1().Ingresses(b.Namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		return false, fmt.Errorf("error confirming ingress watch: %w", err)
+	}
+
+	return true, nil
+}
+
+// DockerBackend pings the Docker daemon's /_ping endpoint.
+type DockerBackend struct {
+	Host               string
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+}
+
+func newDockerBackend(cfg BackendConfig) (DockerBackend, error) {
+	return DockerBackend{
+		Host:               cfg.Host,
+		CACertFile:         cfg.CACertFile,
+		ClientCertFile:     cfg.ClientCertFile,
+		ClientKeyFile:      cfg.ClientKeyFile,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}, nil
+}
+
+func (b DockerBackend) Healthy(ctx context.Context) (bool, error) {
+	opts := []client.Opt{client.WithHost(b.Host)}
+
+	if b.CACertFile != "" || b.ClientCertFile != "" || b.InsecureSkipVerify {
+		tlsConfig, err := buildTLSConfig(b.CACertFile, b.ClientCertFile, b.ClientKeyFile, b.InsecureSkipVerify)
+
+		if err != nil {
+			return false, fmt.Errorf("error building docker TLS config: %w", err)
+		}
+
+		opts = append(opts, client.WithHTTPClient(&http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}))
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
+
+	if err != nil {
+		return false, fmt.Errorf("error building docker client: %w", err)
+	}
+	defer cli.Close()
+
+	if _, err := cli.Ping(ctx); err != nil {
+		return false, fmt.Errorf("error pinging docker daemon: %w", err)
+	}
+
+	return true, nil
+}
+
+// EtcdBackend checks that an etcd cluster has quorum by listing members and
+// confirming a majority respond to a status check.
+type EtcdBackend struct {
+	Host               string
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+}
+
+func newEtcdBackend(cfg BackendConfig) (EtcdBackend, error) {
+	return EtcdBackend{
+		Host:               cfg.Host,
+		CACertFile:         cfg.CACertFile,
+		ClientCertFile:     cfg.ClientCertFile,
+		ClientKeyFile:      cfg.ClientKeyFile,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}, nil
+}
+
+func (b EtcdBackend) Healthy(ctx context.Context) (bool, error) {
+	config := clientv3.Config{
+		Endpoints:   []string{b.Host},
+		DialTimeout: time.Second * 5,
+	}
+
+	if b.CACertFile != "" || b.ClientCertFile != "" || b.InsecureSkipVerify {
+		tlsConfig, err := buildTLSConfig(b.CACertFile, b.ClientCertFile, b.ClientKeyFile, b.InsecureSkipVerify)
+
+		if err != nil {
+			return false, fmt.Errorf("error building etcd TLS config: %w", err)
+		}
+
+		config.TLS = tlsConfig
+	}
+
+	cli, err := clientv3.New(config)
+
+	if err != nil {
+		return false, fmt.Errorf("error building etcd client: %w", err)
+	}
+	defer cli.Close()
+
+	members, err := cli.MemberList(ctx)
+
+	if err != nil {
+		return false, fmt.Errorf("error listing etcd members: %w", err)
+	}
+
+	if len(members.Members) == 0 {
+		return false, fmt.Errorf("etcd reported no members")
+	}
+
+	healthyMembers := 0
+	for _, member := range members.Members {
+		if len(member.ClientURLs) == 0 {
+			continue
+		}
+
+		if _, err := cli.Status(ctx, member.ClientURLs[0]); err == nil {
+			healthyMembers++
+		}
+	}
+
+	if healthyMembers*2 < len(members.Members) {
+		return false, fmt.Errorf("etcd quorum not met: %d/%d members healthy", healthyMembers, len(members.Members))
+	}
+
+	return true, nil
+}
+
+// backendsHealthy builds the configured BackendProviders and requires at
+// least quorum of them to report healthy. A quorum of 0 defaults to
+// requiring all backends to pass. Each backend is retried with backoff and
+// gated by its own circuit breaker before it's counted as failing.
+func backendsHealthy(ctx context.Context, config Configuration) bool {
+	backends := config.Backends
+
+	if len(backends) == 0 {
+		return true
+	}
+
+	quorum := config.BackendQuorum
+	if quorum <= 0 {
+		quorum = len(backends)
+	}
+
+	passing := 0
+	for _, cfg := range backends {
+		provider, err := newBackendProvider(cfg)
+
+		if err != nil {
+			log.WithError(err).Warn("Error building backend provider.")
+			continue
+		}
+
+		key := fmt.Sprintf("backend:%s:%s", cfg.Type, cfg.Host)
+		ok, err := checkWithCircuitBreaker(ctx, key, config, func(ctx context.Context) error {
+			healthy, err := provider.Healthy(ctx)
+
+			if err != nil {
+				return err
+			}
+
+			if !healthy {
+				return fmt.Errorf("backend reported unhealthy")
+			}
+
+			return nil
+		})
+
+		if !ok {
+			log.WithError(err).Warnf("Backend %q (%s) unhealthy.", cfg.Type, cfg.Host)
+			continue
+		}
+
+		passing++
+	}
+
+	return passing >= quorum
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// CircuitState mirrors the HAProxy rise/fall model: a circuit only flips
+// from closed to open after UnhealthyThreshold consecutive failures, and
+// back from open to closed after HealthyThreshold consecutive successes,
+// so a single transient blip can't flip the global healthy flag.
+type CircuitState string
+
+const (
+	CircuitClosed CircuitState = "closed"
+	CircuitOpen   CircuitState = "open"
+)
+
+// CircuitBreaker tracks consecutive results for a single check (a Traefik
+// host, entrypoint, or backend) and decides when it should be considered
+// open or closed.
+type CircuitBreaker struct {
+	mu                   sync.Mutex
+	state                CircuitState
+	consecutiveFailures  int
+	consecutiveSuccesses int
+	unhealthyThreshold   int
+	healthyThreshold     int
+}
+
+func newCircuitBreaker(unhealthyThreshold, healthyThreshold int) *CircuitBreaker {
+	if unhealthyThreshold <= 0 {
+		unhealthyThreshold = 1
+	}
+
+	if healthyThreshold <= 0 {
+		healthyThreshold = 1
+	}
+
+	return &CircuitBreaker{
+		state:              CircuitClosed,
+		unhealthyThreshold: unhealthyThreshold,
+		healthyThreshold:   healthyThreshold,
+	}
+}
+
+// RecordResult records the outcome of one check attempt and returns whether
+// the circuit transitioned state, along with its state after the update.
+func (cb *CircuitBreaker) RecordResult(ok bool) (bool, CircuitState) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if ok {
+		cb.consecutiveSuccesses++
+		cb.consecutiveFailures = 0
+
+		if cb.state == CircuitOpen && cb.consecutiveSuccesses >= cb.healthyThreshold {
+			cb.state = CircuitClosed
+			return true, cb.state
+		}
+
+		return false, cb.state
+	}
+
+	cb.consecutiveFailures++
+	cb.consecutiveSuccesses = 0
+
+	if cb.state == CircuitClosed && cb.consecutiveFailures >= cb.unhealthyThreshold {
+		cb.state = CircuitOpen
+		return true, cb.state
+	}
+
+	return false, cb.state
+}
+
+var (
+	circuitBreakersMu sync.Mutex
+	circuitBreakers   = map[string]*CircuitBreaker{}
+)
+
+// getCircuitBreaker returns the CircuitBreaker for key, creating it with the
+// given thresholds the first time it's seen.
+func getCircuitBreaker(key string, unhealthyThreshold, healthyThreshold int) *CircuitBreaker {
+	circuitBreakersMu.Lock()
+	defer circuitBreakersMu.Unlock()
+
+	breaker, ok := circuitBreakers[key]
+	if !ok {
+		breaker = newCircuitBreaker(unhealthyThreshold, healthyThreshold)
+		circuitBreakers[key] = breaker
+	}
+
+	return breaker
+}
+
+var (
+	circuitStatesMu sync.RWMutex
+	circuitStates   = map[string]CircuitState{}
+)
+
+func setCircuitState(key string, state CircuitState) {
+	circuitStatesMu.Lock()
+	defer circuitStatesMu.Unlock()
+	circuitStates[key] = state
+}
+
+// getCircuitStates returns a snapshot of every known circuit's state, for
+// exposing on the JSON /health endpoint.
+func getCircuitStates() map[string]string {
+	circuitStatesMu.RLock()
+	defer circuitStatesMu.RUnlock()
+
+	states := make(map[string]string, len(circuitStates))
+	for key, state := range circuitStates {
+		states[key] = string(state)
+	}
+
+	return states
+}
+
+// checkWithCircuitBreaker retries check with the configured backoff, feeds
+// the outcome into the named circuit breaker, logs state transitions, and
+// reports whether the circuit currently considers key healthy.
+func checkWithCircuitBreaker(ctx context.Context, key string, config Configuration, check func(ctx context.Context) error) (bool, error) {
+	retryErr := withRetry(
+		ctx,
+		config.Retries,
+		time.Duration(config.RetryInitialInterval)*time.Second,
+		time.Duration(config.RetryMaxInterval)*time.Second,
+		func() error { return check(ctx) },
+	)
+
+	breaker := getCircuitBreaker(key, config.UnhealthyThreshold, config.HealthyThreshold)
+	transitioned, state := breaker.RecordResult(retryErr == nil)
+	setCircuitState(key, state)
+
+	if transitioned {
+		log.WithFields(logrus.Fields{"check": key, "state": state}).Warn("Circuit breaker state transition.")
+	}
+
+	return state == CircuitClosed, retryErr
+}
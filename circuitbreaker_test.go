@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+func TestCircuitBreakerOpensAfterUnhealthyThreshold(t *testing.T) {
+	cb := newCircuitBreaker(3, 2)
+
+	for i := 0; i < 2; i++ {
+		transitioned, state := cb.RecordResult(false)
+
+		if transitioned || state != CircuitClosed {
+			t.Fatalf("failure %d: got (%v, %s), want (false, %s)", i+1, transitioned, state, CircuitClosed)
+		}
+	}
+
+	transitioned, state := cb.RecordResult(false)
+	if !transitioned || state != CircuitOpen {
+		t.Fatalf("3rd consecutive failure: got (%v, %s), want (true, %s)", transitioned, state, CircuitOpen)
+	}
+}
+
+func TestCircuitBreakerClosesAfterHealthyThreshold(t *testing.T) {
+	cb := newCircuitBreaker(1, 2)
+
+	if transitioned, state := cb.RecordResult(false); !transitioned || state != CircuitOpen {
+		t.Fatalf("got (%v, %s), want (true, %s)", transitioned, state, CircuitOpen)
+	}
+
+	if transitioned, state := cb.RecordResult(true); transitioned || state != CircuitOpen {
+		t.Fatalf("1st success: got (%v, %s), want (false, %s)", transitioned, state, CircuitOpen)
+	}
+
+	if transitioned, state := cb.RecordResult(true); !transitioned || state != CircuitClosed {
+		t.Fatalf("2nd consecutive success: got (%v, %s), want (true, %s)", transitioned, state, CircuitClosed)
+	}
+}
+
+func TestCircuitBreakerResetsConsecutiveCountOnAlternatingResults(t *testing.T) {
+	cb := newCircuitBreaker(2, 2)
+
+	cb.RecordResult(false)
+	cb.RecordResult(true) // resets consecutiveFailures to 0
+
+	transitioned, state := cb.RecordResult(false)
+	if transitioned || state != CircuitClosed {
+		t.Fatalf("single failure after reset: got (%v, %s), want (false, %s)", transitioned, state, CircuitClosed)
+	}
+}
+
+func TestNewCircuitBreakerDefaultsNonPositiveThresholds(t *testing.T) {
+	cb := newCircuitBreaker(0, -1)
+
+	transitioned, state := cb.RecordResult(false)
+	if !transitioned || state != CircuitOpen {
+		t.Fatalf("got (%v, %s), want (true, %s) with defaulted threshold of 1", transitioned, state, CircuitOpen)
+	}
+}
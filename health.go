@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CheckStatus is the outcome of a single sub-check (e.g. backends, traefik
+// providers) from the most recent poll cycle.
+type CheckStatus struct {
+	Healthy   bool      `json:"healthy"`
+	Message   string    `json:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// HealthReport is the overall result of a poll cycle, broken down by
+// sub-check, so operators can see why the load balancer was marked
+// unhealthy without tailing logs.
+type HealthReport struct {
+	Healthy   bool                   `json:"healthy"`
+	Timestamp time.Time              `json:"timestamp"`
+	Checks    map[string]CheckStatus `json:"checks"`
+	Circuits  map[string]string      `json:"circuits,omitempty"`
+}
+
+func newHealthReport() *HealthReport {
+	return &HealthReport{
+		Timestamp: time.Now(),
+		Checks:    map[string]CheckStatus{},
+	}
+}
+
+// recordCheck stores the result of a single sub-check on the report being
+// built for the current poll cycle.
+func recordCheck(report *HealthReport, name string, ok bool, err error) {
+	status := CheckStatus{
+		Healthy:   ok,
+		Timestamp: time.Now(),
+	}
+
+	if err != nil {
+		status.Message = err.Error()
+	}
+
+	report.Checks[name] = status
+}
+
+var (
+	lastHealthReportMu sync.RWMutex
+	lastHealthReport   = newHealthReport()
+)
+
+func setLastHealthReport(report *HealthReport) {
+	lastHealthReportMu.Lock()
+	defer lastHealthReportMu.Unlock()
+	lastHealthReport = report
+}
+
+func getLastHealthReport() *HealthReport {
+	lastHealthReportMu.RLock()
+	defer lastHealthReportMu.RUnlock()
+	return lastHealthReport
+}
+
+// healthHandler serves the last poll cycle's HealthReport as JSON, so
+// operators can diagnose why the load balancer was marked unhealthy.
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	report := getLastHealthReport()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if !report.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/sirupsen/logrus"
+	"os"
+)
+
+// log is the package-wide structured logger, configured from Configuration
+// by configureLogging once the config has been loaded.
+var log = logrus.New()
+
+// configureLogging applies the LogLevel and LogJSON settings from the
+// config to the package logger.
+func configureLogging(config Configuration) {
+	log.SetOutput(os.Stdout)
+
+	if config.LogJSON {
+		log.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	level, err := logrus.ParseLevel(config.LogLevel)
+
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+
+	log.SetLevel(level)
+}
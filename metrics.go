@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pollsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "traefik_healthcheck_polls_total",
+		Help: "Total number of health poll cycles run.",
+	})
+
+	checkFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "traefik_healthcheck_check_failures_total",
+		Help: "Total number of failed sub-checks, by check name.",
+	}, []string{"check"})
+
+	pollLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "traefik_healthcheck_poll_duration_seconds",
+		Help:    "Time taken to run a full health poll cycle.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	healthyGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "traefik_healthcheck_healthy",
+		Help: "1 if the load balancer is currently considered healthy, 0 otherwise.",
+	})
+
+	hostServiceCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "traefik_healthcheck_host_service_count",
+		Help: "Number of routers/services reported by a Traefik host, by host and kind.",
+	}, []string{"host", "kind"})
+)
+
+func boolToFloat64(ok bool) float64 {
+	if ok {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// withRetry calls fn until it succeeds or `retries` retries (i.e. up to
+// retries+1 attempts total) are exhausted, backing off exponentially
+// between attempts starting at initialInterval and capping at maxInterval.
+// It returns the last error, or nil as soon as fn succeeds.
+func withRetry(ctx context.Context, retries int, initialInterval, maxInterval time.Duration, fn func() error) error {
+	if initialInterval <= 0 {
+		initialInterval = time.Second
+	}
+
+	interval := initialInterval
+	var err error
+
+	for attempt := 0; attempt <= retries; attempt++ {
+		err = fn()
+
+		if err == nil {
+			return nil
+		}
+
+		if attempt == retries {
+			break
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		interval *= 2
+		if maxInterval > 0 && interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRetrySucceedsWithoutRetrying(t *testing.T) {
+	attempts := 0
+
+	err := withRetry(context.Background(), 3, time.Millisecond, time.Millisecond, func() error {
+		attempts++
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("withRetry() = %v, want nil", err)
+	}
+
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestWithRetryExhaustsAttemptsAndReturnsLastError(t *testing.T) {
+	attempts := 0
+	wantErr := errors.New("boom")
+
+	err := withRetry(context.Background(), 2, time.Millisecond, time.Millisecond, func() error {
+		attempts++
+		return wantErr
+	})
+
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("withRetry() = %v, want %v", err, wantErr)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3 (retries+1)", attempts)
+	}
+}
+
+func TestWithRetryBacksOffExponentiallyUpToMax(t *testing.T) {
+	attempts := 0
+	start := time.Now()
+
+	err := withRetry(context.Background(), 3, 10*time.Millisecond, 15*time.Millisecond, func() error {
+		attempts++
+		return errors.New("boom")
+	})
+
+	if err == nil {
+		t.Fatal("withRetry() = nil, want error")
+	}
+
+	// Intervals are 10ms, 20ms->capped to 15ms, 15ms = 40ms minimum elapsed.
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("elapsed = %v, want at least ~35ms (backoff capped at maxInterval)", elapsed)
+	}
+}
+
+func TestWithRetryStopsOnContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := withRetry(ctx, 5, time.Second, time.Second, func() error {
+		return errors.New("boom")
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("withRetry() = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// minPollInterval is the floor applied to a non-positive PollInterval, so a
+// bad config value can't panic time.NewTicker.
+const minPollInterval = time.Second
+
+// runScheduler drives the health poll loop on a time.Ticker until ctx is
+// cancelled (SIGINT/SIGTERM), at which point it stops ticking and returns so
+// the caller can shut the HTTP server down gracefully.
+func runScheduler(ctx context.Context, config Configuration) {
+	pollInterval := time.Second * time.Duration(config.PollInterval)
+	if pollInterval <= 0 {
+		pollInterval = minPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	runCheck(config)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Stopping health poll scheduler.")
+			return
+		case <-ticker.C:
+			if config.PollJitter > 0 {
+				select {
+				case <-time.After(time.Duration(schedulerRand.Intn(config.PollJitter)) * time.Second):
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			runCheck(config)
+		}
+	}
+}
+
+// defaultCheckTimeout bounds a check cycle when MaxCheckDuration isn't set,
+// so a hung Traefik host still can't stall shutdown forever.
+const defaultCheckTimeout = time.Second * 30
+
+// runCheck runs a single health check cycle, bounding it by MaxCheckDuration
+// (or defaultCheckTimeout) so a hung Traefik host can't stall the whole
+// poller. It's deliberately bound to context.Background() rather than the
+// caller's ctx, so an in-flight check drains to completion on shutdown
+// instead of being aborted the instant SIGINT/SIGTERM cancels ctx.
+func runCheck(config Configuration) {
+	timeout := time.Second * time.Duration(config.MaxCheckDuration)
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+
+	checkCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	isLBHealthy(checkCtx, config)
+	pollLatency.Observe(time.Since(start).Seconds())
+	pollsTotal.Inc()
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// resolveSecret resolves a config value that may be a literal, or an
+// indirection into the environment ("env:NAME") or a file ("file:/path"),
+// so credentials needn't appear in the JSON config verbatim.
+func resolveSecret(value string) string {
+	switch {
+	case strings.HasPrefix(value, "env:"):
+		return os.Getenv(strings.TrimPrefix(value, "env:"))
+	case strings.HasPrefix(value, "file:"):
+		data, err := os.ReadFile(strings.TrimPrefix(value, "file:"))
+
+		if err != nil {
+			log.WithError(err).Warn("Error reading secret file.")
+			return ""
+		}
+
+		return strings.TrimSpace(string(data))
+	default:
+		return value
+	}
+}
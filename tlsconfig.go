@@ -0,0 +1,119 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// BasicAuthConfig holds HTTP basic auth credentials for a Traefik API.
+// Password supports the same "env:"/"file:" indirection as BearerToken and
+// the Consul Token, so it needn't appear in the JSON config verbatim.
+type BasicAuthConfig struct {
+	User     string
+	Password string
+}
+
+// buildTLSConfig builds a *tls.Config from a CA cert, client cert/key and
+// insecure-skip-verify setting, as configured on a TraefikHost or
+// BackendConfig. All fields are optional; an empty caCertFile/clientCertFile
+// leaves the corresponding tls.Config field unset.
+func buildTLSConfig(caCertFile, clientCertFile, clientKeyFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+
+	if caCertFile != "" {
+		caCert, err := os.ReadFile(caCertFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA cert file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid certificates found in %s", caCertFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCertFile != "" && clientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("error loading client cert/key: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// buildTraefikHTTPClient constructs the *http.Client for a single Traefik
+// host, honouring its TLS settings (CA cert, client cert/key, and whether
+// to skip verification, which now defaults to false).
+func buildTraefikHTTPClient(host TraefikHost) (*http.Client, error) {
+	tlsConfig, err := buildTLSConfig(host.CACertFile, host.ClientCertFile, host.ClientKeyFile, host.InsecureSkipVerify)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{TLSClientConfig: tlsConfig}
+
+	return &http.Client{
+		Timeout:   time.Second * 10,
+		Transport: transport,
+	}, nil
+}
+
+var (
+	traefikHTTPClientsMu sync.Mutex
+	traefikHTTPClients   = map[string]*http.Client{}
+)
+
+// getTraefikHTTPClient returns the cached *http.Client for a host's TLS
+// settings, building it the first time that host is checked.
+func getTraefikHTTPClient(host TraefikHost) (*http.Client, error) {
+	return getCachedTraefikHTTPClient(host.Host, host)
+}
+
+// getCachedTraefikHTTPClient returns the cached *http.Client for cacheKey,
+// building it from host's TLS settings the first time that key is checked.
+// Callers that aren't keyed on a TraefikHost's Host field (e.g. entrypoint
+// checks, keyed on URL) pass their own cacheKey.
+func getCachedTraefikHTTPClient(cacheKey string, host TraefikHost) (*http.Client, error) {
+	traefikHTTPClientsMu.Lock()
+	defer traefikHTTPClientsMu.Unlock()
+
+	if client, ok := traefikHTTPClients[cacheKey]; ok {
+		return client, nil
+	}
+
+	client, err := buildTraefikHTTPClient(host)
+
+	if err != nil {
+		return nil, err
+	}
+
+	traefikHTTPClients[cacheKey] = client
+
+	return client, nil
+}
+
+// applyTraefikAuth sets BasicAuth or BearerToken credentials on an outgoing
+// Traefik API request, if the host is configured with any.
+func applyTraefikAuth(request *http.Request, host TraefikHost) {
+	if host.BasicAuth != nil && host.BasicAuth.User != "" {
+		request.SetBasicAuth(host.BasicAuth.User, resolveSecret(host.BasicAuth.Password))
+		return
+	}
+
+	if host.BearerToken != "" {
+		request.Header.Set("Authorization", "Bearer "+resolveSecret(host.BearerToken))
+	}
+}
@@ -1,34 +1,69 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"github.com/hashicorp/consul/api"
+	"fmt"
 	"github.com/pborman/getopt/v2"
-	"log"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
-	"crypto/tls"
 	"math/rand"
 )
 
 // Configuration settings.
 type TraefikHost struct {
-	Host        string
-	MinServices int
+	Host               string
+	ApiVersion         string
+	MinServices        int
+	MinHTTPRouters     int
+	MinTCPRouters      int
+	MinUDPRouters      int
+	Scheme             string
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+	BasicAuth          *BasicAuthConfig
+	BearerToken        string
+}
+
+// EntrypointConfig describes a single Traefik entrypoint URL to poll, with
+// the same TLS/auth options as TraefikHost.
+type EntrypointConfig struct {
+	URL                string
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+	BasicAuth          *BasicAuthConfig
+	BearerToken        string
 }
 
 type Configuration struct {
-	ListenAddr         string
-	PollInterval       int
-	TraefikHosts       []TraefikHost
-	ConsulHost         string
-	TraefikEntrypoints []string
-	HealthyTTLSec      int
-	HealthyTTLOffset   int
+	ListenAddr           string
+	PollInterval         int
+	TraefikHosts         []TraefikHost
+	Backends             []BackendConfig
+	BackendQuorum        int
+	TraefikEntrypoints   []EntrypointConfig
+	HealthyTTLSec        int
+	HealthyTTLOffset     int
+	LogLevel             string
+	LogJSON              bool
+	PollJitter           int
+	MaxCheckDuration     int
+	Retries              int
+	RetryInitialInterval int
+	RetryMaxInterval     int
+	UnhealthyThreshold   int
+	HealthyThreshold     int
 }
 
-// Traefik providers endpoint struct for json response.
+// Traefik providers endpoint struct for json response. Used by the v1 API.
 type TraefikProviders struct {
 	ConsulCatalog struct {
 		Backends  map[string]interface{} `json:"backends"`
@@ -43,8 +78,267 @@ type TraefikHealth struct {
 	RequestCount int `json:"total_count"`
 }
 
-// Global variable to determine if the load-balancer is healthy or not.
-var healthy bool
+// Traefik v2/v3 version endpoint response, used to auto-detect the API version in use.
+type TraefikVersion struct {
+	Version string `json:"Version"`
+}
+
+// processStart records when this process started, used as an uptime fallback
+// for Traefik hosts that don't expose the v1 /health endpoint.
+var processStart = time.Now()
+
+// TraefikClient abstracts over the Traefik v1 and v2/v3 API shapes so
+// traefikIsHealthy doesn't need to care which version a host is running.
+type TraefikClient interface {
+	// CheckServices confirms the host reports at least the configured
+	// minimum number of services/routers/frontends/backends.
+	CheckServices(ctx context.Context, host TraefikHost, httpClient *http.Client) error
+	// CheckUptime enforces the TTL against the host's reported uptime,
+	// or a fallback when the host can't report one.
+	CheckUptime(ctx context.Context, host TraefikHost, httpClient *http.Client, ttl int) error
+}
+
+// V1Client talks to the Traefik v1 /api/providers and /health endpoints.
+type V1Client struct{}
+
+func (c V1Client) CheckServices(ctx context.Context, host TraefikHost, httpClient *http.Client) error {
+	response, err := traefikGet(ctx, httpClient, host, scheme(host)+"://"+host.Host+"/api/providers")
+
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return fmt.Errorf("error fetching traefik providers, got status code %d", response.StatusCode)
+	}
+
+	providers := TraefikProviders{}
+	decoder := json.NewDecoder(response.Body)
+	if err := decoder.Decode(&providers); err != nil {
+		return err
+	}
+
+	hostServiceCount.WithLabelValues(host.Host, "backends").Set(float64(len(providers.ConsulCatalog.Backends)))
+	hostServiceCount.WithLabelValues(host.Host, "frontends").Set(float64(len(providers.ConsulCatalog.Frontends)))
+
+	if len(providers.ConsulCatalog.Backends) < host.MinServices {
+		return fmt.Errorf("no backends found in Traefik")
+	}
+
+	if len(providers.ConsulCatalog.Frontends) < host.MinServices {
+		return fmt.Errorf("no frontends found in Traefik")
+	}
+
+	return nil
+}
+
+func (c V1Client) CheckUptime(ctx context.Context, host TraefikHost, httpClient *http.Client, ttl int) error {
+	if ttl == 0 {
+		return nil
+	}
+
+	response, err := traefikGet(ctx, httpClient, host, scheme(host)+"://"+host.Host+"/health")
+
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return fmt.Errorf("error fetching traefik health, got status code %d", response.StatusCode)
+	}
+
+	health := TraefikHealth{}
+	decoder := json.NewDecoder(response.Body)
+	if err := decoder.Decode(&health); err != nil {
+		return err
+	}
+
+	if int(health.UptimeSec) > ttl {
+		return fmt.Errorf("server %s reached max ttl of %d", host.Host, ttl)
+	}
+
+	return nil
+}
+
+// V2Client talks to the Traefik v2/v3 /api/http/*, /api/tcp/*, /api/udp/*
+// and /ping endpoints. v2 removed /health, so uptime is enforced against
+// processStart instead.
+type V2Client struct{}
+
+func (c V2Client) CheckServices(ctx context.Context, host TraefikHost, httpClient *http.Client) error {
+	routers, err := fetchTraefikList(ctx, httpClient, host, scheme(host)+"://"+host.Host+"/api/http/routers")
+	if err != nil {
+		return err
+	}
+
+	services, err := fetchTraefikList(ctx, httpClient, host, scheme(host)+"://"+host.Host+"/api/http/services")
+	if err != nil {
+		return err
+	}
+
+	middlewares, err := fetchTraefikList(ctx, httpClient, host, scheme(host)+"://"+host.Host+"/api/http/middlewares")
+	if err != nil {
+		return err
+	}
+
+	hostServiceCount.WithLabelValues(host.Host, "http_routers").Set(float64(len(routers)))
+	hostServiceCount.WithLabelValues(host.Host, "http_services").Set(float64(len(services)))
+	hostServiceCount.WithLabelValues(host.Host, "http_middlewares").Set(float64(len(middlewares)))
+
+	if len(routers) < host.MinServices {
+		return fmt.Errorf("no http routers found in Traefik")
+	}
+
+	if len(services) < host.MinServices {
+		return fmt.Errorf("no http services found in Traefik")
+	}
+
+	if len(middlewares) < host.MinServices {
+		return fmt.Errorf("no http middlewares found in Traefik")
+	}
+
+	if host.MinHTTPRouters > 0 && len(routers) < host.MinHTTPRouters {
+		return fmt.Errorf("expected at least %d http routers, got %d", host.MinHTTPRouters, len(routers))
+	}
+
+	if host.MinTCPRouters > 0 {
+		tcpRouters, err := fetchTraefikList(ctx, httpClient, host, scheme(host)+"://"+host.Host+"/api/tcp/routers")
+		if err != nil {
+			return err
+		}
+
+		hostServiceCount.WithLabelValues(host.Host, "tcp_routers").Set(float64(len(tcpRouters)))
+
+		if len(tcpRouters) < host.MinTCPRouters {
+			return fmt.Errorf("expected at least %d tcp routers, got %d", host.MinTCPRouters, len(tcpRouters))
+		}
+	}
+
+	if host.MinUDPRouters > 0 {
+		udpRouters, err := fetchTraefikList(ctx, httpClient, host, scheme(host)+"://"+host.Host+"/api/udp/routers")
+		if err != nil {
+			return err
+		}
+
+		hostServiceCount.WithLabelValues(host.Host, "udp_routers").Set(float64(len(udpRouters)))
+
+		if len(udpRouters) < host.MinUDPRouters {
+			return fmt.Errorf("expected at least %d udp routers, got %d", host.MinUDPRouters, len(udpRouters))
+		}
+	}
+
+	return nil
+}
+
+func (c V2Client) CheckUptime(ctx context.Context, host TraefikHost, httpClient *http.Client, ttl int) error {
+	if ttl == 0 {
+		return nil
+	}
+
+	response, err := traefikGet(ctx, httpClient, host, scheme(host)+"://"+host.Host+"/ping")
+
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return fmt.Errorf("error contacting traefik ping endpoint, got status code %d", response.StatusCode)
+	}
+
+	if uptime := int(time.Since(processStart).Seconds()); uptime > ttl {
+		return fmt.Errorf("process uptime fallback reached max ttl of %d", ttl)
+	}
+
+	return nil
+}
+
+// traefikGet issues a GET request bound to ctx, so a hung Traefik host
+// aborts as soon as the caller's context expires rather than stalling
+// until the client's own timeout. It applies host's configured BasicAuth
+// or BearerToken credentials, if any.
+func traefikGet(ctx context.Context, httpClient *http.Client, host TraefikHost, url string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	applyTraefikAuth(request, host)
+
+	return httpClient.Do(request)
+}
+
+// fetchTraefikList decodes a v2/v3 API list endpoint, which simply returns a
+// JSON array of router/service/middleware objects.
+func fetchTraefikList(ctx context.Context, httpClient *http.Client, host TraefikHost, url string) ([]interface{}, error) {
+	response, err := traefikGet(ctx, httpClient, host, url)
+
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return nil, fmt.Errorf("error fetching %s, got status code %d", url, response.StatusCode)
+	}
+
+	var list []interface{}
+	decoder := json.NewDecoder(response.Body)
+	if err := decoder.Decode(&list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// detectApiVersion probes /api/version to determine whether a host is
+// running Traefik v1 or v2/v3. Hosts that don't respond (v1 never shipped
+// this endpoint) are assumed to be v1.
+func detectApiVersion(ctx context.Context, host TraefikHost, httpClient *http.Client) string {
+	response, err := traefikGet(ctx, httpClient, host, scheme(host)+"://"+host.Host+"/api/version")
+
+	if err != nil || response.StatusCode != 200 {
+		return "v1"
+	}
+	defer response.Body.Close()
+
+	version := TraefikVersion{}
+	decoder := json.NewDecoder(response.Body)
+	if err := decoder.Decode(&version); err != nil || version.Version == "" {
+		return "v1"
+	}
+
+	return "v2"
+}
+
+// traefikClientFor resolves the TraefikClient to use for a host, honouring
+// an explicit ApiVersion and otherwise auto-detecting it.
+func traefikClientFor(ctx context.Context, host TraefikHost, httpClient *http.Client) TraefikClient {
+	apiVersion := host.ApiVersion
+
+	if apiVersion == "" {
+		apiVersion = detectApiVersion(ctx, host, httpClient)
+	}
+
+	if apiVersion == "v2" || apiVersion == "v3" {
+		return V2Client{}
+	}
+
+	return V1Client{}
+}
+
+// scheme returns the host's configured Scheme, defaulting to "http" for
+// hosts that don't set one.
+func scheme(host TraefikHost) string {
+	if host.Scheme == "" {
+		return "http"
+	}
+
+	return host.Scheme
+}
 
 func main() {
 	configFile := getopt.String('c', "./traefik-healthcheck.json", "The path to the traefik-healthcheck config file.", "string")
@@ -52,32 +346,72 @@ func main() {
 	opts := getopt.CommandLine
 	opts.Parse(os.Args)
 
-	log.Print("Starting Traefik Healthcheck...")
-	log.Printf("Using config file \"%s\"", *configFile)
-
 	config := newConfig(*configFile)
+	configureLogging(config)
+
+	log.Info("Starting Traefik Healthcheck...")
+	log.Infof("Using config file \"%s\"", *configFile)
+	log.Infof("Server TTL Seconds: %d", config.HealthyTTLSec)
 
-	log.Printf("Server TTL Seconds: %d", config.HealthyTTLSec)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	go pollHealth(config)
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		runScheduler(ctx, config)
+	}()
 
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if !healthy {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if !getLastHealthReport().Healthy {
 			w.WriteHeader(http.StatusInternalServerError)
 		} else {
 			w.WriteHeader(http.StatusOK)
 		}
 	})
 
-	log.Printf("HTTP server listening on: %s", config.ListenAddr)
-	log.Fatal(http.ListenAndServe(config.ListenAddr, nil))
+	mux.HandleFunc("/health", healthHandler)
+	mux.Handle("/metrics", promhttp.Handler())
+
+	server := &http.Server{
+		Addr:    config.ListenAddr,
+		Handler: mux,
+	}
+
+	go func() {
+		log.Infof("HTTP server listening on: %s", config.ListenAddr)
+
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Fatal("HTTP server failed.")
+		}
+	}()
+
+	<-ctx.Done()
+	log.Info("Shutting down, draining in-flight checks...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.WithError(err).Warn("Error shutting down HTTP server.")
+	}
 
-	log.Println("Fnished.")
+	<-schedulerDone
+	log.Info("Scheduler drained, exiting.")
 }
 
+// schedulerRand is seeded once at startup and reused for TTL jitter and
+// poll jitter, rather than reseeding math/rand on every call.
+var schedulerRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+
 func computeTtl(ttl int, offset int) int {
-	rand.Seed(time.Now().Unix())
-	return rand.Intn(offset - 0) + ttl
+	if offset <= 0 {
+		return ttl
+	}
+
+	return schedulerRand.Intn(offset) + ttl
 }
 
 // Create a new configuration setup.
@@ -87,11 +421,16 @@ func newConfig(path string) Configuration {
 		MinServices: 0,
 	}}
 
+	defaultBackends := []BackendConfig{{
+		Type: "consul",
+		Host: "127.0.0.1:8500",
+	}}
+
 	config := Configuration{
 		ListenAddr:       "0.0.0.0:10700",
 		PollInterval:     10,
 		TraefikHosts:     defaultHosts,
-		ConsulHost:       "127.0.0.1:8500",
+		Backends:         defaultBackends,
 		HealthyTTLSec:    0,
 		HealthyTTLOffset: 43200,
 	}
@@ -115,140 +454,148 @@ func newConfig(path string) Configuration {
 	return config
 }
 
-// Check that consul is healthy.
-func consulIsHealthy(consulAddress string) bool {
-	config := api.Config{
-		Address: consulAddress,
-	}
+// checkTraefikProviders confirms every Traefik host reports the configured
+// minimum number of services/routers/frontends/backends. Each host is
+// retried with backoff and gated by its own circuit breaker, so one flaky
+// host can't immediately flip the whole check unhealthy.
+func checkTraefikProviders(ctx context.Context, config Configuration) error {
+	var lastErr error
 
-	client, err := api.NewClient(&config)
+	for _, host := range config.TraefikHosts {
+		httpClient, err := getTraefikHTTPClient(host)
+		if err != nil {
+			lastErr = fmt.Errorf("host %s: %w", host.Host, err)
+			continue
+		}
 
-	if err != nil {
-		log.Print("Error connecting to consul client.", err)
-		return false
-	}
+		client := traefikClientFor(ctx, host, httpClient)
 
-	status := client.Status()
-	leader, err := status.Leader()
+		ok, err := checkWithCircuitBreaker(ctx, "traefik_providers:"+host.Host, config, func(ctx context.Context) error {
+			return client.CheckServices(ctx, host, httpClient)
+		})
 
-	if err != nil {
-		log.Print("Error querying consul leader.", err)
-		return false
-	}
-
-	if leader != "" {
-		return true
+		if !ok {
+			lastErr = fmt.Errorf("host %s: %w", host.Host, err)
+		}
 	}
 
-	return false
+	return lastErr
 }
 
-// Check traefik is healthy.
-func traefikIsHealthy(traefikHosts []TraefikHost, traefikEntrypoints []string, ttl int) bool {
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-	var traefikClient = &http.Client{
-		Timeout: time.Second * 10,
-		Transport: transport,
-	}
-
-	for _, host := range traefikHosts {
-		response, err := traefikClient.Get("http://" + host.Host + "/api/providers")
+// checkTraefikTTL enforces the uptime TTL against every Traefik host.
+func checkTraefikTTL(ctx context.Context, config Configuration, ttl int) error {
+	var lastErr error
 
+	for _, host := range config.TraefikHosts {
+		httpClient, err := getTraefikHTTPClient(host)
 		if err != nil {
-			log.Print("Error contacting traefik providers endpoint.", err)
-			return false
-		}
-
-		if response.StatusCode != 200 {
-			log.Printf("Error fetching traefik providers. Got status code %d", response.StatusCode)
-			response.Body.Close()
-			return false
+			lastErr = fmt.Errorf("host %s: %w", host.Host, err)
+			continue
 		}
 
-		providers := TraefikProviders{}
-		decoder := json.NewDecoder(response.Body)
-		err = decoder.Decode(&providers)
+		client := traefikClientFor(ctx, host, httpClient)
 
-		if err != nil {
-			log.Print(err)
-			response.Body.Close()
-			return false
-		}
+		ok, err := checkWithCircuitBreaker(ctx, "traefik_ttl:"+host.Host, config, func(ctx context.Context) error {
+			return client.CheckUptime(ctx, host, httpClient, ttl)
+		})
 
-		if len(providers.ConsulCatalog.Backends) < host.MinServices {
-			log.Print("No backends found in Traefik.")
-			response.Body.Close()
-			return false
+		if !ok {
+			lastErr = fmt.Errorf("host %s: %w", host.Host, err)
 		}
+	}
 
-		if len(providers.ConsulCatalog.Frontends) < host.MinServices {
-			log.Print("No frontends found in Traefik.")
-			response.Body.Close()
-			return false
-		}
+	return lastErr
+}
 
-		response.Body.Close()
+// entrypointAuth adapts an EntrypointConfig's TLS/auth fields to a
+// TraefikHost, so entrypoint checks can reuse buildTraefikHTTPClient and
+// applyTraefikAuth instead of duplicating that logic.
+func entrypointAuth(entrypoint EntrypointConfig) TraefikHost {
+	return TraefikHost{
+		CACertFile:         entrypoint.CACertFile,
+		ClientCertFile:     entrypoint.ClientCertFile,
+		ClientKeyFile:      entrypoint.ClientKeyFile,
+		InsecureSkipVerify: entrypoint.InsecureSkipVerify,
+		BasicAuth:          entrypoint.BasicAuth,
+		BearerToken:        entrypoint.BearerToken,
 	}
+}
+
+// checkTraefikEntrypoints confirms every configured entrypoint URL responds
+// without a server error, honouring each entrypoint's own TLS/auth settings.
+func checkTraefikEntrypoints(ctx context.Context, config Configuration) error {
+	var lastErr error
 
-	if ttl != 0 {
-		for _, host := range traefikHosts {
-			response, err := traefikClient.Get("http://" + host.Host + "/health")
+	for _, entrypoint := range config.TraefikEntrypoints {
+		entrypoint := entrypoint
+		host := entrypointAuth(entrypoint)
+
+		httpClient, err := getCachedTraefikHTTPClient(entrypoint.URL, host)
+		if err != nil {
+			lastErr = fmt.Errorf("entrypoint %s: %w", entrypoint.URL, err)
+			continue
+		}
+
+		ok, err := checkWithCircuitBreaker(ctx, "traefik_entrypoint:"+entrypoint.URL, config, func(ctx context.Context) error {
+			response, err := traefikGet(ctx, httpClient, host, entrypoint.URL)
 
 			if err != nil {
-				log.Print("Error contacting traefik providers endpoint.", err)
-				return false
+				return err
 			}
+			defer response.Body.Close()
 
-			if response.StatusCode != 200 {
-				log.Printf("Error fetching traefik providers. Got status code %d", response.StatusCode)
-				response.Body.Close()
-				return false
+			if response.StatusCode >= 500 {
+				return fmt.Errorf("got status code %d", response.StatusCode)
 			}
 
-			health := TraefikHealth{}
-			decoder := json.NewDecoder(response.Body)
-			err = decoder.Decode(&health)
-
-			if int(health.UptimeSec) > ttl {
-				log.Printf("Server %s reached max ttl of %d", host.Host, ttl)
-				response.Body.Close()
-				return false
-			}
+			return nil
+		})
 
-			response.Body.Close()
+		if !ok {
+			lastErr = fmt.Errorf("entrypoint %s: %w", entrypoint.URL, err)
 		}
 	}
 
-	for _, host := range traefikEntrypoints {
-		response, err := traefikClient.Get(host)
+	return lastErr
+}
 
-		if err != nil {
-			log.Print("Error contacting traefik entrypoint.", err)
-			return false
-		}
+// Check the overall load balancer is healthy, recording the result of each
+// sub-check so operators can inspect it via the /health endpoint. ctx bounds
+// how long the whole check is allowed to run for.
+func isLBHealthy(ctx context.Context, config Configuration) bool {
+	report := newHealthReport()
 
-		if response.StatusCode >= 500 {
-			log.Printf("Error checking entrypoint response. Got status code %d", response.StatusCode)
-			response.Body.Close()
-			return false
-		}
+	backendsOK := backendsHealthy(ctx, config)
+	recordCheck(report, "backends", backendsOK, nil)
+	if !backendsOK {
+		checkFailuresTotal.WithLabelValues("backends").Inc()
+	}
 
-		response.Body.Close()
+	providersErr := checkTraefikProviders(ctx, config)
+	recordCheck(report, "traefik_providers", providersErr == nil, providersErr)
+	if providersErr != nil {
+		log.WithError(providersErr).Warn("Traefik providers check failed.")
+		checkFailuresTotal.WithLabelValues("traefik_providers").Inc()
 	}
 
-	return true
-}
+	ttlErr := checkTraefikTTL(ctx, config, config.HealthyTTLSec)
+	recordCheck(report, "traefik_ttl", ttlErr == nil, ttlErr)
+	if ttlErr != nil {
+		log.WithError(ttlErr).Warn("Traefik TTL check failed.")
+		checkFailuresTotal.WithLabelValues("ttl_exceeded").Inc()
+	}
 
-// Check the overall load balancer is healthy.
-func isLBHealthy(config Configuration) bool {
-	return consulIsHealthy(config.ConsulHost) && traefikIsHealthy(config.TraefikHosts, config.TraefikEntrypoints, config.HealthyTTLSec)
-}
+	entrypointsErr := checkTraefikEntrypoints(ctx, config)
+	recordCheck(report, "traefik_entrypoints", entrypointsErr == nil, entrypointsErr)
+	if entrypointsErr != nil {
+		log.WithError(entrypointsErr).Warn("Traefik entrypoints check failed.")
+		checkFailuresTotal.WithLabelValues("traefik_entrypoints").Inc()
+	}
+
+	report.Healthy = backendsOK && providersErr == nil && ttlErr == nil && entrypointsErr == nil
+	report.Circuits = getCircuitStates()
+	setLastHealthReport(report)
+	healthyGauge.Set(boolToFloat64(report.Healthy))
 
-// Poll for health changes and save to the global healthy variable.
-func pollHealth(config Configuration) {
-	healthy = isLBHealthy(config)
-	time.Sleep(time.Second * time.Duration(config.PollInterval))
-	pollHealth(config)
+	return report.Healthy
 }
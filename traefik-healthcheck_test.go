@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestComputeTtl(t *testing.T) {
+	t.Run("offset <= 0 returns ttl unchanged", func(t *testing.T) {
+		if got := computeTtl(100, 0); got != 100 {
+			t.Errorf("computeTtl(100, 0) = %d, want 100", got)
+		}
+
+		if got := computeTtl(100, -5); got != 100 {
+			t.Errorf("computeTtl(100, -5) = %d, want 100", got)
+		}
+	})
+
+	t.Run("offset > 0 jitters within [ttl, ttl+offset)", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			got := computeTtl(100, 50)
+
+			if got < 100 || got >= 150 {
+				t.Fatalf("computeTtl(100, 50) = %d, want in [100, 150)", got)
+			}
+		}
+	})
+}